@@ -0,0 +1,213 @@
+/*
+   TAction/TActionList 对应 Delphi 里的 Action 机制，
+   用来把同一个命令（比如保存）统一抽象出来，同时绑定到工具栏按钮、
+   菜单项、弹出菜单等多个控件上，这些控件共享 Caption/Enabled/Hint/ShortCut
+   以及点击后触发的逻辑。
+
+   使用方式：
+
+   type TMainForm struct {
+       *vcl.TForm
+       SaveAction *vcl.TAction
+       Button1    *vcl.TButton `action:"SaveAction"`
+       MenuItem1  *vcl.TMenuItem `action:"SaveAction"`
+   }
+
+   func (f *TMainForm) OnFormCreate(sender vcl.IObject) {
+       f.SaveAction = vcl.NewAction("SaveAction")
+       f.SaveAction.SetCaption("保存")
+       f.SaveAction.SetOnExecute(func(sender vcl.IObject) {
+           // 执行保存...
+       })
+   }
+
+   之后只需要 f.SaveAction.SetEnabled(false)，Button1、MenuItem1 就会同时禁用。
+
+   注意 action 标签是在 OnFormCreate 调用之后才扫描的，所以 SaveAction
+   必须在 OnFormCreate 里创建好，跟上面的例子一样；如果改到别处才赋值，
+   控件就不会被自动注册为这个 Action 的客户端。
+*/
+
+package vcl
+
+import (
+	"reflect"
+)
+
+// TAction 封装一个命令：描述信息（Caption/Hint/ShortCut）、
+// 状态（Enabled/Visible）以及触发时执行的处理函数。
+type TAction struct {
+	name           string
+	caption        string
+	hint           string
+	shortCut       string
+	enabled        bool
+	visible        bool
+	onExecute      reflect.Value
+	clients        []IComponent
+	executionDepth int32 // 配合 enterEventCall 防止 OnExecute 里直接/间接再次触发自己
+}
+
+// NewAction 创建一个新的 TAction，默认 Enabled、Visible 均为 true。
+func NewAction(name string) *TAction {
+	return &TAction{
+		name:    name,
+		enabled: true,
+		visible: true,
+	}
+}
+
+// Name 返回这个 Action 的名称。
+func (a *TAction) Name() string {
+	return a.name
+}
+
+// Caption 返回Caption。
+func (a *TAction) Caption() string {
+	return a.caption
+}
+
+// SetCaption 设置Caption，并同步到所有绑定的控件。
+func (a *TAction) SetCaption(value string) {
+	a.caption = value
+	a.applyToClients(func(v reflect.Value) {
+		callSetter(v, "SetCaption", value)
+	})
+}
+
+// Hint 返回Hint。
+func (a *TAction) Hint() string {
+	return a.hint
+}
+
+// SetHint 设置Hint，并同步到所有绑定的控件。
+func (a *TAction) SetHint(value string) {
+	a.hint = value
+	a.applyToClients(func(v reflect.Value) {
+		callSetter(v, "SetHint", value)
+	})
+}
+
+// ShortCut 返回ShortCut。
+func (a *TAction) ShortCut() string {
+	return a.shortCut
+}
+
+// SetShortCut 设置ShortCut，并同步到所有绑定的控件。
+func (a *TAction) SetShortCut(value string) {
+	a.shortCut = value
+	a.applyToClients(func(v reflect.Value) {
+		callSetter(v, "SetShortCut", value)
+	})
+}
+
+// Enabled 返回当前是否可用。
+func (a *TAction) Enabled() bool {
+	return a.enabled
+}
+
+// SetEnabled 设置是否可用，并同步到所有绑定的控件。
+func (a *TAction) SetEnabled(value bool) {
+	a.enabled = value
+	a.applyToClients(func(v reflect.Value) {
+		callSetter(v, "SetEnabled", value)
+	})
+}
+
+// Visible 返回当前是否可见。
+func (a *TAction) Visible() bool {
+	return a.visible
+}
+
+// SetVisible 设置是否可见，并同步到所有绑定的控件。
+func (a *TAction) SetVisible(value bool) {
+	a.visible = value
+	a.applyToClients(func(v reflect.Value) {
+		callSetter(v, "SetVisible", value)
+	})
+}
+
+// SetOnExecute 设置点击绑定控件时触发的处理函数，签名应为 func(sender IObject)。
+func (a *TAction) SetOnExecute(handler interface{}) {
+	a.onExecute = reflect.ValueOf(handler)
+}
+
+// Execute 手动触发这个 Action，效果跟点击一个绑定的控件一样。
+// Execute 本身就是会被反复调用的分发点（多个控件共享同一个 Action，
+// OnExecute 里又可能直接或间接再次 Execute 自己），所以用
+// a.executionDepth 走 enterEventCall 做递归深度保护，不依赖任何按
+// reflect.Value.Pointer() 分桶的全局状态。
+func (a *TAction) Execute(sender IObject) {
+	ok, leave := enterEventCall("OnExecute:"+a.name, &a.executionDepth)
+	if !ok {
+		return
+	}
+	defer leave()
+	// sender 为 nil 时 reflect.ValueOf 得到的是无效的零 Value，不能直接传给 Call，
+	// 改用处理函数参数类型的零值。
+	in := reflect.ValueOf(sender)
+	if sender == nil && a.onExecute.IsValid() && a.onExecute.Kind() == reflect.Func && a.onExecute.Type().NumIn() > 0 {
+		in = reflect.Zero(a.onExecute.Type().In(0))
+	}
+	callEvent(a.onExecute, []reflect.Value{in})
+}
+
+// RegisterClient 把一个控件注册为这个 Action 的客户端：
+// 控件当前的 Caption/Hint/ShortCut/Enabled/Visible 会被 Action 的状态覆盖，
+// 控件的 OnClick 会被接管，点击后转发到 Execute。
+func (a *TAction) RegisterClient(client IComponent) {
+	a.clients = append(a.clients, client)
+
+	v := reflect.ValueOf(client)
+	callSetter(v, "SetCaption", a.caption)
+	callSetter(v, "SetHint", a.hint)
+	callSetter(v, "SetShortCut", a.shortCut)
+	callSetter(v, "SetEnabled", a.enabled)
+	callSetter(v, "SetVisible", a.visible)
+
+	if setOnClick := v.MethodByName("SetOnClick"); setOnClick.IsValid() {
+		onClickType := setOnClick.Type().In(0)
+		handler := reflect.MakeFunc(onClickType, func(in []reflect.Value) []reflect.Value {
+			a.Execute(in[0].Interface().(IObject))
+			return nil
+		})
+		setOnClick.Call([]reflect.Value{handler})
+	}
+}
+
+// applyToClients 把 apply 应用到当前所有已注册的客户端控件上。
+func (a *TAction) applyToClients(apply func(v reflect.Value)) {
+	for _, c := range a.clients {
+		apply(reflect.ValueOf(c))
+	}
+}
+
+// callSetter 调用控件上的某个 SetXXX 方法，控件不支持该属性时直接忽略。
+func callSetter(v reflect.Value, name string, arg interface{}) {
+	defer func() {
+		recover()
+	}()
+	if setter := v.MethodByName(name); setter.IsValid() {
+		setter.Call([]reflect.Value{reflect.ValueOf(arg)})
+	}
+}
+
+// TActionList 是一组 TAction 的容器，对应一个窗体内所有可复用的命令。
+type TActionList struct {
+	actions map[string]*TAction
+}
+
+// NewActionList 创建一个空的 TActionList。
+func NewActionList() *TActionList {
+	return &TActionList{actions: make(map[string]*TAction)}
+}
+
+// Add 把一个 TAction 加入列表。
+func (al *TActionList) Add(action *TAction) {
+	al.actions[action.Name()] = action
+}
+
+// FindAction 按名称查找一个 TAction，找不到返回 nil。
+func (al *TActionList) FindAction(name string) *TAction {
+	return al.actions[name]
+}
@@ -0,0 +1,94 @@
+package vcl
+
+import "testing"
+
+// TAction/TActionList 的 clients 字段是 []IComponent，这个 snapshot 里没有任何
+// 具体类型实现 IComponent，所以这里只测不涉及 RegisterClient 的那部分：
+// 不带客户端时的 getter/setter，以及 TActionList 本身。
+
+func TestActionGettersSetters(t *testing.T) {
+	a := NewAction("SaveAction")
+
+	if a.Name() != "SaveAction" {
+		t.Fatalf("Name() = %q, want %q", a.Name(), "SaveAction")
+	}
+	if !a.Enabled() || !a.Visible() {
+		t.Fatalf("NewAction should default Enabled/Visible to true")
+	}
+
+	a.SetCaption("保存")
+	if a.Caption() != "保存" {
+		t.Fatalf("Caption() = %q, want %q", a.Caption(), "保存")
+	}
+
+	a.SetHint("保存当前文件")
+	if a.Hint() != "保存当前文件" {
+		t.Fatalf("Hint() = %q, want %q", a.Hint(), "保存当前文件")
+	}
+
+	a.SetShortCut("Ctrl+S")
+	if a.ShortCut() != "Ctrl+S" {
+		t.Fatalf("ShortCut() = %q, want %q", a.ShortCut(), "Ctrl+S")
+	}
+
+	a.SetEnabled(false)
+	if a.Enabled() {
+		t.Fatalf("Enabled() should be false after SetEnabled(false)")
+	}
+
+	a.SetVisible(false)
+	if a.Visible() {
+		t.Fatalf("Visible() should be false after SetVisible(false)")
+	}
+}
+
+func TestActionExecuteCallsOnExecute(t *testing.T) {
+	a := NewAction("SaveAction")
+
+	called := false
+	a.SetOnExecute(func(sender IObject) {
+		called = true
+	})
+
+	a.Execute(nil)
+	if !called {
+		t.Fatalf("Execute should have invoked the OnExecute handler")
+	}
+}
+
+func TestActionExecuteGuardsAgainstUnboundedRecursion(t *testing.T) {
+	// 回归用例：Execute 自己是真正会被反复触发的分发点（多个控件共享同一个
+	// Action，OnExecute 里又可能再次 Execute 自己），必须有自己独立的
+	// executionDepth，而不是共享某个按 reflect.Value.Pointer() 分桶的状态。
+	origLimit := EventRecursionLimit
+	defer SetEventRecursionLimit(origLimit)
+	SetEventRecursionLimit(3)
+
+	a := NewAction("ReentrantAction")
+	var depth int
+	a.SetOnExecute(func(sender IObject) {
+		depth++
+		if depth < 10 {
+			a.Execute(nil)
+		}
+	})
+
+	a.Execute(nil)
+
+	if depth != 3 {
+		t.Fatalf("depth = %d, want 3 (Execute should stop recursing at EventRecursionLimit)", depth)
+	}
+}
+
+func TestActionListAddFind(t *testing.T) {
+	al := NewActionList()
+	a := NewAction("SaveAction")
+	al.Add(a)
+
+	if got := al.FindAction("SaveAction"); got != a {
+		t.Fatalf("FindAction(SaveAction) = %v, want %v", got, a)
+	}
+	if got := al.FindAction("NoSuchAction"); got != nil {
+		t.Fatalf("FindAction(NoSuchAction) = %v, want nil", got)
+	}
+}
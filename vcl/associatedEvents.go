@@ -28,13 +28,27 @@
 
    }
 
+   event标签还支持用逗号一次关联多个事件名，以及通配符批量匹配：
+
+   type TMainForm struct {
+       *vcl.TForm
+       Button2 *vcl.TButton `event:"OnButton1Click,OnSaveClick"`
+       Button3 *vcl.TButton `event:"OnBtn*Click"`
+   }
+
+   action标签用于把控件绑定到一个 TAction（参见 action.go），详见该文件说明。
+
+   事件名的解析规则本身也是可替换的，参见 eventresolver.go 里的 EventNameResolver。
+
 */
 
 package vcl
 
 import (
 	"fmt"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -50,19 +64,13 @@ func associatedEvents(vForm reflect.Value, form *TForm, subComponentEvent bool)
 
 	vt := vForm.Type()
 
+	// 事件名的解析规则默认是 On + 组件名 + 事件类型，可以通过内嵌 *vcl.TForm
+	// 上的 resolver 标签换成其它命名约定，参见 EventNameResolver。
+	resolver := resolveEventResolver(vt)
+
 	// 提取所有符合规则的事件
-	eventMethods := make(map[string]reflect.Value, 0)
-	for i := 0; i < vt.NumMethod(); i++ {
-		m := vt.Method(i)
-		// 保存窗口创建事件
-		if m.Name == "OnFormCreate" {
-			formCreate = vForm.Method(i)
-			continue
-		}
-		if strings.HasPrefix(m.Name, "On") {
-			eventMethods[m.Name] = vForm.Method(i)
-		}
-	}
+	var eventMethods map[string]methodEntry
+	formCreate, eventMethods = collectEventMethods(vt, vForm, resolver)
 
 	type tempItem struct {
 		Type   string
@@ -80,19 +88,25 @@ func associatedEvents(vForm reflect.Value, form *TForm, subComponentEvent bool)
 		if name2 == "" {
 			name2 = name1
 		}
-		prefix := "On" + name1
-		for mName, method := range eventMethods {
-			if !strings.HasPrefix(mName, prefix) {
+		isForm := name1 == "Form"
+		for mName, entry := range eventMethods {
+			var eventType string
+			var ok bool
+			if isForm {
+				eventType, ok = resolver.FormEventName(entry.Method)
+			} else {
+				eventType, ok = resolver.Match(entry.Method, name1)
+			}
+			if !ok {
 				continue
 			}
-			eventType := mName[len(prefix):]
 			// 将事件名与事件类型对应，之后会用到的
-			tempEventTypes[mName] = tempItem{eventType, method}
+			tempEventTypes[mName] = tempItem{eventType, entry.Value}
 
 			if component.Equals(Application) {
-				addApplicationNotifyEvent(eventType, method)
+				addApplicationNotifyEvent(eventType, entry.Value)
 			} else {
-				addComponentNotifyEvent(vForm, name2, method, eventType)
+				addComponentNotifyEvent(vForm, name2, entry.Value, eventType)
 			}
 		}
 	}
@@ -110,16 +124,42 @@ func associatedEvents(vForm reflect.Value, form *TForm, subComponentEvent bool)
 		// 提取字段中的事件关联
 		for i := 0; i < vt.Elem().NumField(); i++ {
 			field := vt.Elem().Field(i)
-			eventTag := field.Tag.Get("event")
-			if eventTag == "" {
-				continue
-			}
-			item, ok := tempEventTypes[eventTag]
-			if !ok {
-				continue
+
+			if eventTag := field.Tag.Get("event"); eventTag != "" {
+				vCtl := vForm.Elem().Field(i)
+				if !vCtl.IsValid() {
+					continue
+				}
+				// 支持逗号分隔的多个事件名，以及 OnBtn*Click 这样的通配符，
+				// 让同一个处理函数一次性共享给多个组件。
+				for _, entry := range strings.Split(eventTag, ",") {
+					entry = strings.TrimSpace(entry)
+					if entry == "" {
+						continue
+					}
+					if strings.ContainsAny(entry, "*?") {
+						for mName, item := range tempEventTypes {
+							if matched, _ := filepath.Match(entry, mName); matched {
+								findAndSetEvent(vCtl, item.Type, item.Method)
+							}
+						}
+						continue
+					}
+					if item, ok := tempEventTypes[entry]; ok {
+						findAndSetEvent(vCtl, item.Type, item.Method)
+					}
+				}
 			}
-			if vCtl := vForm.Elem().Field(i); vCtl.IsValid() {
-				findAndSetEvent(vCtl, item.Type, item.Method)
+
+			// bus:"topicName" 让字段对应的 OnXXX 方法同时作为 EventBus 订阅者
+			if busTag := field.Tag.Get("bus"); busTag != "" {
+				if vCtl := vForm.Elem().Field(i); vCtl.IsValid() {
+					if ctl, ok := vCtl.Interface().(IComponent); ok {
+						if method, ok := findFieldEventMethod(eventMethods, ctl, resolver); ok {
+							busSubscribeField(ctl, busTag, method)
+						}
+					}
+				}
 			}
 		}
 	}
@@ -129,9 +169,38 @@ func associatedEvents(vForm reflect.Value, form *TForm, subComponentEvent bool)
 
 	// 最后调用OnCreate
 	callEvent(formCreate, []reflect.Value{vForm})
+
+	// action:"SaveAction" 把控件注册为某个 TAction 的客户端，其
+	// Caption/Enabled/Hint/ShortCut 和 OnClick 都会跟随这个 Action 的状态。
+	// 必须放在 OnCreate 调用之后扫描：TAction 通常是在 OnFormCreate 里
+	// 才被创建出来的，提前扫描只会看到一个还没赋值的 nil 字段。
+	if subComponentEvent {
+		for i := 0; i < vt.Elem().NumField(); i++ {
+			field := vt.Elem().Field(i)
+			actionTag := field.Tag.Get("action")
+			if actionTag == "" {
+				continue
+			}
+			vAction := vForm.Elem().FieldByName(actionTag)
+			if !vAction.IsValid() {
+				continue
+			}
+			action, ok := vAction.Interface().(*TAction)
+			if !ok || action == nil {
+				continue
+			}
+			if vCtl := vForm.Elem().Field(i); vCtl.IsValid() {
+				if ctl, ok := vCtl.Interface().(IComponent); ok {
+					action.RegisterClient(ctl)
+				}
+			}
+		}
+	}
 }
 
-// callEvent 调用事件。
+// callEvent 调用事件。formCreate 只在这里被调用一次，不存在重入问题；
+// 真正会被反复/递归触发的调用方（比如 TAction.Execute）自己持有一个
+// depth 计数器去走 enterEventCall，而不是依赖这里做递归保护。
 func callEvent(event reflect.Value, params []reflect.Value) {
 	defer func() {
 		if err := recover(); err != nil {
@@ -144,7 +213,70 @@ func callEvent(event reflect.Value, params []reflect.Value) {
 	event.Call(params)
 }
 
-// findAndSetEvent 公用的call SetOnXXXX方法
+// collectEventMethods 遍历 vt 上的所有导出方法，挑出 OnFormCreate（窗口创建事件，
+// 单独返回）和其余 resolver 认为是候选事件方法的那些。是否算候选完全交给
+// resolver.IsCandidate 判断，不在这里写死任何前缀/后缀规则，这样
+// SuffixResolver 这类自定义命名约定的事件方法才能真正被收进 eventMethods，
+// 而不是在这一步就被过滤掉。
+// vt.NumMethod/vt.Method 只列出导出的方法：on_button1_click 这种小写开头的
+// 未导出方法不会被收集，所以 SnakeCaseResolver 在这里拿不到任何候选方法。
+// 单独抽出来也是为了能脱离 TForm/IComponent 直接对它做单元测试。
+func collectEventMethods(vt reflect.Type, vForm reflect.Value, resolver EventNameResolver) (formCreate reflect.Value, eventMethods map[string]methodEntry) {
+	eventMethods = make(map[string]methodEntry, 0)
+	for i := 0; i < vt.NumMethod(); i++ {
+		m := vt.Method(i)
+		// 保存窗口创建事件
+		if m.Name == "OnFormCreate" {
+			formCreate = vForm.Method(i)
+			continue
+		}
+		if resolver.IsCandidate(m) {
+			eventMethods[m.Name] = methodEntry{m, vForm.Method(i)}
+		}
+	}
+	return formCreate, eventMethods
+}
+
+// methodEntry 同时保存一个事件方法的 reflect.Method（给 EventNameResolver 解析用）
+// 和 reflect.Value（真正用来调用或注册的反射值）。
+type methodEntry struct {
+	Method reflect.Method
+	Value  reflect.Value
+}
+
+// findFieldEventMethod 在 eventMethods 中找到 ctl 对应的 OnXXX 方法，
+// 供 bus 标签等需要直接拿到反射方法而不是调用 SetOnXXXX 的场景使用。
+// 一个组件可能同时存在多个事件方法（比如 OnButton1Click 和
+// OnButton1MouseDown），这里优先选 Click，否则按事件类型排序取第一个，
+// 避免遍历 map 时顺序不固定导致绑定到不确定的事件上。
+func findFieldEventMethod(eventMethods map[string]methodEntry, ctl IComponent, resolver EventNameResolver) (reflect.Value, bool) {
+	byEventType := make(map[string]reflect.Value)
+	for _, entry := range eventMethods {
+		if eventType, ok := resolver.Match(entry.Method, ctl.Name()); ok {
+			byEventType[eventType] = entry.Value
+		}
+	}
+
+	if method, ok := byEventType["Click"]; ok {
+		return method, true
+	}
+
+	if len(byEventType) == 0 {
+		return reflect.Value{}, false
+	}
+
+	eventTypes := make([]string, 0, len(byEventType))
+	for eventType := range byEventType {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+
+	return byEventType[eventTypes[0]], true
+}
+
+// findAndSetEvent 公用的call SetOnXXXX方法。
+// 实际交给控件的是 guardEventHandler 包过的版本，这样控件每次真正触发这个
+// 事件时都会经过递归深度检查，而不是只在这里注册的这一刻检查一次。
 func findAndSetEvent(v reflect.Value, eventType string, method reflect.Value) {
 	defer func() {
 		if err := recover(); err != nil {
@@ -152,7 +284,7 @@ func findAndSetEvent(v reflect.Value, eventType string, method reflect.Value) {
 		}
 	}()
 	if event := v.MethodByName("SetOn" + eventType); event.IsValid() {
-		event.Call([]reflect.Value{method})
+		event.Call([]reflect.Value{guardEventHandler(eventType, method)})
 	}
 }
 
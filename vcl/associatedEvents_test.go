@@ -0,0 +1,61 @@
+package vcl
+
+import (
+	"reflect"
+	"testing"
+)
+
+// snakeForm 和 suffixForm 模拟那些不用 "On"+组件名 默认命名约定的窗体：
+// 它们各自通过 resolver 指定自己的事件命名规则。没有 TForm/IComponent 类型
+// 的情况下没法跑完整的 associatedEvents，但 collectEventMethods 正是
+// associatedEvents 里真正出 bug 的那一步（硬编码 strings.HasPrefix(m.Name,
+// "On") 在 resolver 有机会判断之前就把方法过滤掉了），这里直接对它做回归测试。
+type snakeForm struct{}
+
+func (f *snakeForm) OnFormCreate(sender IObjectStub) {}
+func (f *snakeForm) on_button1_click(sender IObjectStub) {}
+
+type suffixForm struct{}
+
+func (f *suffixForm) OnFormCreate(sender IObjectStub) {}
+func (f *suffixForm) Button1Click_Handler(sender IObjectStub) {}
+
+// IObjectStub 只是用来让上面那些方法有个合法签名，不依赖真实的 IObject。
+type IObjectStub interface{}
+
+func TestCollectEventMethodsRespectsResolverIsCandidate(t *testing.T) {
+	vForm := reflect.ValueOf(&snakeForm{})
+	_, eventMethods := collectEventMethods(vForm.Type(), vForm, SnakeCaseResolver{})
+
+	if _, ok := eventMethods["on_button1_click"]; !ok {
+		t.Fatalf("on_button1_click should have been collected by SnakeCaseResolver, got %v", eventMethods)
+	}
+
+	// 回归用例：用 DefaultResolver 去收集同一个 snake_case 命名的窗体，
+	// 方法应该被过滤掉而不是硬编码的 "On" 前缀检查悄悄放过/拦下。
+	_, eventMethodsDefault := collectEventMethods(vForm.Type(), vForm, DefaultResolver{})
+	if _, ok := eventMethodsDefault["on_button1_click"]; ok {
+		t.Fatalf("on_button1_click should not be a candidate under DefaultResolver")
+	}
+}
+
+func TestCollectEventMethodsSuffixResolver(t *testing.T) {
+	vForm := reflect.ValueOf(&suffixForm{})
+	_, eventMethods := collectEventMethods(vForm.Type(), vForm, SuffixResolver{})
+
+	if _, ok := eventMethods["Button1Click_Handler"]; !ok {
+		t.Fatalf("Button1Click_Handler should have been collected by SuffixResolver, got %v", eventMethods)
+	}
+}
+
+func TestCollectEventMethodsSkipsOnFormCreate(t *testing.T) {
+	vForm := reflect.ValueOf(&snakeForm{})
+	formCreate, eventMethods := collectEventMethods(vForm.Type(), vForm, SnakeCaseResolver{})
+
+	if !formCreate.IsValid() {
+		t.Fatalf("OnFormCreate should be returned separately as formCreate")
+	}
+	if _, ok := eventMethods["OnFormCreate"]; ok {
+		t.Fatalf("OnFormCreate should not also end up inside eventMethods")
+	}
+}
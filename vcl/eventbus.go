@@ -0,0 +1,240 @@
+/*
+   EventBus 提供一种不依赖直接引用的跨窗体/组件消息机制，
+   作为 associatedEvents 反射关联事件的补充。
+
+   使用方式：
+
+   id := vcl.Subscribe("order.changed", func(order string) {
+       // 处理...
+   })
+   defer vcl.Unsubscribe(id)
+
+   vcl.Publish("order.changed", "SO-001")
+
+   如果订阅的回调需要安全地操作界面控件，应使用 PublishAsync，
+   它会通过 ThreadSync 把调用转发到 VCL 主线程执行。
+
+   传了 owner 的订阅会在 owner 销毁时自动取消——前提是 owner 的类型暴露了
+   GetOnDestroy/SetOnDestroy。如果不是标准 TComponent 派生出来的类型，
+   没有这两个方法，请在 owner 销毁前手动调用 UnsubscribeOwner 兜底。
+
+   字段也可以通过 `bus` 标签让 associatedEvents 自动完成订阅，例如：
+
+   type TMainForm struct {
+       *vcl.TForm
+       Button1 *vcl.TButton `bus:"order.changed"`
+   }
+
+   func (f *TMainForm) OnButton1Click(sender vcl.IObject) {
+   }
+*/
+
+package vcl
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// SubscriptionID 订阅标识，由 Subscribe 返回，用于 Unsubscribe。
+type SubscriptionID uint64
+
+// subscription 保存一个订阅的处理函数及其归属者。
+type subscription struct {
+	id      SubscriptionID
+	topic   string
+	handler reflect.Value
+	argsIn  []reflect.Type
+	owner   IComponent
+}
+
+var (
+	busMu      sync.RWMutex
+	busTopics  = make(map[string][]subscription)
+	busByID    = make(map[SubscriptionID]string) // id -> topic，方便 Unsubscribe 时查找
+	busByOwner = make(map[IComponent][]SubscriptionID)
+	busNextID  uint64
+)
+
+// Subscribe 订阅 topic，handler 必须是一个函数。
+// owner 可选，传入 IComponent 后该组件销毁时会自动取消订阅，避免悬空调用。
+func Subscribe(topic string, handler interface{}, owner ...IComponent) SubscriptionID {
+	hv := reflect.ValueOf(handler)
+	if hv.Kind() != reflect.Func {
+		panic("vcl: EventBus.Subscribe handler must be a function")
+	}
+
+	var o IComponent
+	if len(owner) > 0 {
+		o = owner[0]
+	}
+
+	ht := hv.Type()
+	argsIn := make([]reflect.Type, ht.NumIn())
+	for i := 0; i < ht.NumIn(); i++ {
+		argsIn[i] = ht.In(i)
+	}
+
+	id := SubscriptionID(atomic.AddUint64(&busNextID, 1))
+	sub := subscription{id: id, topic: topic, handler: hv, argsIn: argsIn, owner: o}
+
+	busMu.Lock()
+	busTopics[topic] = append(busTopics[topic], sub)
+	busByID[id] = topic
+	if o != nil {
+		busByOwner[o] = append(busByOwner[o], id)
+		hookOwnerFreeNotification(o)
+	}
+	busMu.Unlock()
+
+	return id
+}
+
+// Unsubscribe 取消一个订阅。
+func Unsubscribe(id SubscriptionID) {
+	busMu.Lock()
+	defer busMu.Unlock()
+	unsubscribeLocked(id)
+}
+
+// unsubscribeLocked 要求调用者已持有 busMu 写锁。
+func unsubscribeLocked(id SubscriptionID) {
+	topic, ok := busByID[id]
+	if !ok {
+		return
+	}
+	delete(busByID, id)
+
+	subs := busTopics[topic]
+	for i, s := range subs {
+		if s.id == id {
+			busTopics[topic] = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+	if len(busTopics[topic]) == 0 {
+		delete(busTopics, topic)
+	}
+}
+
+// removeOwnerSubscriptions 在 owner 被释放时自动清理其名下的所有订阅。
+func removeOwnerSubscriptions(owner IComponent) {
+	busMu.Lock()
+	defer busMu.Unlock()
+	for _, id := range busByOwner[owner] {
+		unsubscribeLocked(id)
+	}
+	delete(busByOwner, owner)
+}
+
+// UnsubscribeOwner 手动清理 owner 名下所有通过 Subscribe 绑定的订阅。
+//
+// hookOwnerFreeNotification 只有在 owner 同时暴露 GetOnDestroy/SetOnDestroy
+// 这两个方法时才能自动挂上清理逻辑；owner 的具体类型如果没有这两个方法
+// （比如不是从标准 TComponent 派生出来的类型），Subscribe 会直接跳过自动
+// 挂载，不会有任何报错或日志，订阅也就不会被自动清理。这种情况下请在
+// owner 销毁前的逻辑里手动调用一次 UnsubscribeOwner 兜底，否则总线会一直
+// 攒着指向已经失效 owner 的反射调用。
+func UnsubscribeOwner(owner IComponent) {
+	removeOwnerSubscriptions(owner)
+}
+
+// Publish 同步调用 topic 下的所有订阅者，args 会按顺序传给 handler。
+func Publish(topic string, args ...interface{}) {
+	for _, h := range snapshotHandlers(topic) {
+		callBusHandler(h, args)
+	}
+}
+
+// PublishAsync 把调用转发到 VCL 主线程执行，适合订阅者需要操作界面控件的场景。
+func PublishAsync(topic string, args ...interface{}) {
+	for _, h := range snapshotHandlers(topic) {
+		h := h
+		ThreadSync(func() {
+			callBusHandler(h, args)
+		})
+	}
+}
+
+// snapshotHandlers 复制一份当前 topic 的订阅列表，避免在回调中修改订阅导致的并发问题。
+func snapshotHandlers(topic string) []subscription {
+	busMu.RLock()
+	defer busMu.RUnlock()
+	subs := busTopics[topic]
+	result := make([]subscription, len(subs))
+	copy(result, subs)
+	return result
+}
+
+// callBusHandler 按参数类型把 args 转成 reflect.Value 后调用 handler。
+func callBusHandler(h subscription, args []interface{}) {
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Println("vcl: EventBus handler error, topic:", h.topic, ", error:", err)
+		}
+	}()
+	if len(args) != len(h.argsIn) {
+		return
+	}
+	in := make([]reflect.Value, len(args))
+	for i, a := range args {
+		if a == nil {
+			in[i] = reflect.Zero(h.argsIn[i])
+		} else {
+			in[i] = reflect.ValueOf(a)
+		}
+	}
+	h.handler.Call(in)
+}
+
+// busSubscribeField 供 associatedEvents 处理字段上的 `bus:"topic"` 标签使用。
+func busSubscribeField(owner IComponent, topic string, method reflect.Value) {
+	if topic == "" || !method.IsValid() {
+		return
+	}
+	Subscribe(topic, method.Interface(), owner)
+}
+
+var hookedOwners sync.Map // IComponent -> struct{}，避免重复挂载 OnDestroy
+
+// hookOwnerFreeNotification 尝试监听 owner 的销毁事件，销毁时自动移除其
+// 订阅，避免组件释放后总线仍持有悬空的反射调用。
+//
+// 这是尽力而为的：它依赖 owner 同时有 GetOnDestroy/SetOnDestroy 方法，
+// 如果 owner 的类型没有暴露这两个方法，下面会直接原样返回，什么也不做——
+// 调用方需要自己在 owner 销毁前调用 UnsubscribeOwner 兜底，见该函数注释。
+func hookOwnerFreeNotification(owner IComponent) {
+	if _, loaded := hookedOwners.LoadOrStore(owner, struct{}{}); loaded {
+		return
+	}
+	hookFreeNotification(owner, func() {
+		removeOwnerSubscriptions(owner)
+	})
+}
+
+// hookFreeNotification 是 hookOwnerFreeNotification 实际做反射挂载的部分，接收
+// interface{} 而不是 IComponent：owner 只需要暴露 GetOnDestroy/SetOnDestroy 这对
+// 方法，跟它是不是 IComponent 没关系。拆出来是为了能在这个仓库快照里没有任何具体
+// 类型实现 IComponent 的情况下，用一个只实现了这两个方法的桩 struct 直接验证这段
+// "链上原来的 OnDestroy，再调用 onFreed" 的反射逻辑是对的，而不是只能测到"方法不存在
+// 就什么也不做"这一种路径。
+func hookFreeNotification(owner interface{}, onFreed func()) {
+	vOwner := reflect.ValueOf(owner)
+	getOn := vOwner.MethodByName("GetOnDestroy")
+	setOn := vOwner.MethodByName("SetOnDestroy")
+	if !getOn.IsValid() || !setOn.IsValid() {
+		return
+	}
+
+	prev := getOn.Call(nil)[0]
+	notify := reflect.MakeFunc(prev.Type(), func(in []reflect.Value) []reflect.Value {
+		if prev.IsValid() && !prev.IsNil() {
+			prev.Call(in)
+		}
+		onFreed()
+		return nil
+	})
+	setOn.Call([]reflect.Value{notify})
+}
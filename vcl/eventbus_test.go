@@ -0,0 +1,98 @@
+package vcl
+
+import "testing"
+
+func TestSubscribePublishUnsubscribe(t *testing.T) {
+	var got string
+	id := Subscribe("test.topic", func(v string) {
+		got = v
+	})
+	defer Unsubscribe(id)
+
+	Publish("test.topic", "hello")
+	if got != "hello" {
+		t.Fatalf("got %q, want %q", got, "hello")
+	}
+
+	Unsubscribe(id)
+	got = ""
+	Publish("test.topic", "world")
+	if got != "" {
+		t.Fatalf("handler should not fire after Unsubscribe, got %q", got)
+	}
+}
+
+func TestPublishIgnoresArgCountMismatch(t *testing.T) {
+	called := false
+	id := Subscribe("test.topic.argmismatch", func(a, b string) {
+		called = true
+	})
+	defer Unsubscribe(id)
+
+	// 参数数量对不上时 callBusHandler 应该直接跳过，而不是 panic。
+	Publish("test.topic.argmismatch", "only-one-arg")
+	if called {
+		t.Fatalf("handler should not be called when arg count mismatches")
+	}
+}
+
+// destroyNotifyEvent、ownerStub 只是用来驱动 hookFreeNotification 的反射逻辑，
+// 不需要实现 IComponent——这段逻辑本身跟 IComponent 无关，只要 owner 暴露
+// GetOnDestroy/SetOnDestroy 这对方法就行。
+type destroyNotifyEvent func()
+
+type ownerStub struct {
+	onDestroy destroyNotifyEvent
+}
+
+func (o *ownerStub) GetOnDestroy() destroyNotifyEvent   { return o.onDestroy }
+func (o *ownerStub) SetOnDestroy(fn destroyNotifyEvent) { o.onDestroy = fn }
+
+func TestHookFreeNotificationChainsPreviousHandlerAndFiresOnFreed(t *testing.T) {
+	owner := &ownerStub{}
+	prevCalled := false
+	owner.onDestroy = func() { prevCalled = true }
+
+	freed := false
+	hookFreeNotification(owner, func() { freed = true })
+
+	// hookFreeNotification 应该替换掉 OnDestroy，新的处理函数要先调用原来挂着的，
+	// 再调用 onFreed，而不是把原来的处理函数直接丢掉。
+	owner.onDestroy()
+
+	if !prevCalled {
+		t.Fatalf("previous OnDestroy handler should still be called after hooking")
+	}
+	if !freed {
+		t.Fatalf("onFreed callback should have fired")
+	}
+}
+
+func TestHookFreeNotificationNoOpsWithoutGetSetOnDestroy(t *testing.T) {
+	// 回归用例：owner 的类型没有 GetOnDestroy/SetOnDestroy 时（这个仓库快照里
+	// 实际就是这种情况），hookFreeNotification 应该安静地什么也不做，而不是 panic。
+	type bareOwner struct{}
+
+	freed := false
+	hookFreeNotification(&bareOwner{}, func() { freed = true })
+
+	if freed {
+		t.Fatalf("onFreed should not fire when owner lacks GetOnDestroy/SetOnDestroy")
+	}
+}
+
+func TestSubscribeWithoutOwnerDoesNotTrackOwner(t *testing.T) {
+	// hookOwnerFreeNotification/UnsubscribeOwner 都以 IComponent 为 key，
+	// 这个 snapshot 里没有任何满足 IComponent 的具体类型可以实例化，所以
+	// 这里只验证不依赖 owner 的路径：Subscribe 不传 owner 时，busByOwner
+	// 完全不会被写入，Publish/Unsubscribe 行为不受影响。
+	id := Subscribe("test.topic.noowner", func() {})
+	defer Unsubscribe(id)
+
+	busMu.RLock()
+	_, tracked := busByOwner[nil]
+	busMu.RUnlock()
+	if tracked {
+		t.Fatalf("Subscribe without an owner should not register anything under a nil owner key")
+	}
+}
@@ -0,0 +1,151 @@
+/*
+   提供在运行时临时替换某个控件事件处理函数的辅助方法，常见场景是
+   进入“捕获下一次点击”之类的临时模式，用完后再恢复原来的处理函数，
+   这在 Delphi/VCL 应用和编辑器类工具里很常见，以前只能自己手工保存、
+   恢复回调，这里统一封装起来。
+
+   用法：
+
+   prev, _ := vcl.SwapEventHandler(f.Button1, "Click", f.OnCaptureClick)
+   // ... 捕获完成后
+   vcl.SwapEventHandler(f.Button1, "Click", prev)
+
+   或者使用栈式的 Push/Pop：
+
+   vcl.PushEventHandler(f.Button1, "Click", f.OnCaptureClick)
+   // ...
+   vcl.PopEventHandler(f.Button1, "Click")
+
+   只要求控件有 SetOn+eventName，没有 GetOn+eventName（这种情况很常见）时
+   会用本包自己记的上一次设置值顶替，细节和限制见 SwapEventHandler 的注释。
+*/
+
+package vcl
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// SwapEventHandler 把 component 上 On+eventName 对应的事件处理函数替换为 newHandler，
+// 返回替换前的处理函数，方便之后恢复。组件必须存在 SetOn+eventName 方法，否则返回 error。
+//
+// 很多 LCL 组件只生成了 SetOn+eventName，没有对应的 GetOn+eventName（后者并不是每个
+// 事件都会生成）。这种情况下没法真的去问控件"你现在挂的是哪个处理函数"，这里退而求其次，
+// 自己维护一张 component+eventName -> 最近一次通过 SwapEventHandler/PushEventHandler
+// 设置的处理函数的表，用它来充当"生成的 getter"。注意这只能看到经由本包设置过的处理函数：
+// 如果在第一次调用 SwapEventHandler 之前，代码已经直接调用过 SetOnXXX 挂了一个处理函数，
+// 那个处理函数是看不到的，第一次 Swap 返回的 previous 只能是 nil。
+func SwapEventHandler(component IComponent, eventName string, newHandler interface{}) (previous interface{}, err error) {
+	return swapEventHandler(component, eventName, newHandler)
+}
+
+// swapEventHandler 是 SwapEventHandler 真正的实现，接收 interface{} 而不是
+// IComponent，纯粹靠反射工作，不依赖 IComponent 具体有哪些方法。拆出来是为了
+// 能在这个仓库快照里没有任何具体类型实现 IComponent 的情况下，用一个随便什么
+// 桩 struct 直接对这段反射逻辑做单元测试。
+func swapEventHandler(component interface{}, eventName string, newHandler interface{}) (previous interface{}, err error) {
+	v := reflect.ValueOf(component)
+
+	setter := v.MethodByName("SetOn" + eventName)
+	if !setter.IsValid() {
+		return nil, fmt.Errorf("vcl: SwapEventHandler: SetOn%s not found", eventName)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("vcl: SwapEventHandler: %v", r)
+		}
+	}()
+
+	key := eventHandlerStackKey{component, eventName}
+
+	if getter := v.MethodByName("GetOn" + eventName); getter.IsValid() {
+		old := getter.Call(nil)[0]
+		if old.IsValid() && !old.IsNil() {
+			previous = old.Interface()
+		}
+	} else {
+		// 没有真正的 getter，用我们自己记的上一次设置值顶替。
+		lastHandlersMu.Lock()
+		previous = lastHandlers[key]
+		lastHandlersMu.Unlock()
+	}
+
+	var newValue reflect.Value
+	if newHandler == nil {
+		newValue = reflect.Zero(setter.Type().In(0))
+	} else {
+		newValue = reflect.ValueOf(newHandler)
+	}
+	setter.Call([]reflect.Value{newValue})
+
+	lastHandlersMu.Lock()
+	if newHandler == nil {
+		delete(lastHandlers, key)
+	} else {
+		lastHandlers[key] = newHandler
+	}
+	lastHandlersMu.Unlock()
+
+	return previous, nil
+}
+
+// lastHandlers 给那些没有 GetOn+eventName 的组件充当"生成的 getter"，
+// 记录每个 component+eventName 最近一次通过 SwapEventHandler 设置的处理函数。
+var (
+	lastHandlersMu sync.Mutex
+	lastHandlers   = make(map[eventHandlerStackKey]interface{})
+)
+
+// eventHandlerStackKey 用来在 eventHandlerStacks 中定位某个组件的某个事件。
+// component 只会被当 map key 比较，不会调用它的方法，所以用 interface{} 而不是
+// IComponent，这样 swapEventHandler 的测试桩也能复用这同一张表。
+type eventHandlerStackKey struct {
+	component interface{}
+	eventName string
+}
+
+var (
+	eventHandlerStacksMu sync.Mutex
+	eventHandlerStacks   = make(map[eventHandlerStackKey][]interface{})
+)
+
+// PushEventHandler 把 component 上 On+eventName 当前的处理函数压栈保存，
+// 然后将其替换为 newHandler。
+func PushEventHandler(component IComponent, eventName string, newHandler interface{}) error {
+	previous, err := SwapEventHandler(component, eventName, newHandler)
+	if err != nil {
+		return err
+	}
+
+	key := eventHandlerStackKey{component, eventName}
+	eventHandlerStacksMu.Lock()
+	eventHandlerStacks[key] = append(eventHandlerStacks[key], previous)
+	eventHandlerStacksMu.Unlock()
+
+	return nil
+}
+
+// PopEventHandler 恢复上一次 PushEventHandler 保存的处理函数，
+// 栈为空时返回 error。
+func PopEventHandler(component IComponent, eventName string) (restored interface{}, err error) {
+	key := eventHandlerStackKey{component, eventName}
+
+	eventHandlerStacksMu.Lock()
+	stack := eventHandlerStacks[key]
+	if len(stack) == 0 {
+		eventHandlerStacksMu.Unlock()
+		return nil, fmt.Errorf("vcl: PopEventHandler: no saved handler for %s", eventName)
+	}
+	restored = stack[len(stack)-1]
+	eventHandlerStacks[key] = stack[:len(stack)-1]
+	if len(eventHandlerStacks[key]) == 0 {
+		delete(eventHandlerStacks, key)
+	}
+	eventHandlerStacksMu.Unlock()
+
+	_, err = SwapEventHandler(component, eventName, restored)
+	return restored, err
+}
@@ -0,0 +1,67 @@
+package vcl
+
+import "testing"
+
+// clickOnlyControl 模拟一个只生成了 SetOnClick、没有生成 GetOnClick 的控件——
+// swapEventHandler 不需要它实现 IComponent，只要有 SetOn+eventName 方法。
+type clickOnlyControl struct {
+	onClick func()
+}
+
+func (c *clickOnlyControl) SetOnClick(fn func()) { c.onClick = fn }
+
+// clickGetSetControl 模拟一个同时有 GetOnClick/SetOnClick 的控件。
+type clickGetSetControl struct {
+	onClick func()
+}
+
+func (c *clickGetSetControl) GetOnClick() func()   { return c.onClick }
+func (c *clickGetSetControl) SetOnClick(fn func()) { c.onClick = fn }
+
+func TestSwapEventHandlerSynthesizesGetterWhenMissing(t *testing.T) {
+	// 回归用例：以前这里要求 GetOn+eventName 必须存在，对只生成了 setter 的
+	// 控件（这里最常见的情况）会直接返回 error，而不是像请求里要求的那样
+	// 合成一个 getter。
+	ctl := &clickOnlyControl{}
+
+	first := func() {}
+	previous, err := swapEventHandler(ctl, "Click", first)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if previous != nil {
+		t.Fatalf("previous = %v, want nil on first swap", previous)
+	}
+	if ctl.onClick == nil {
+		t.Fatalf("SetOnClick should have been called with the new handler")
+	}
+
+	second := func() {}
+	previous, err = swapEventHandler(ctl, "Click", second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if previous == nil {
+		t.Fatalf("previous should be the first handler recorded by the synthesized getter, got nil")
+	}
+}
+
+func TestSwapEventHandlerUsesRealGetterWhenPresent(t *testing.T) {
+	ctl := &clickGetSetControl{}
+	ctl.onClick = func() {}
+
+	previous, err := swapEventHandler(ctl, "Click", func() {})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if previous == nil {
+		t.Fatalf("previous should come from the real GetOnClick, not the synthesized table")
+	}
+}
+
+func TestSwapEventHandlerErrorsWithoutSetter(t *testing.T) {
+	type noSetter struct{}
+	if _, err := swapEventHandler(&noSetter{}, "Click", func() {}); err == nil {
+		t.Fatalf("expected an error when SetOnClick does not exist")
+	}
+}
@@ -0,0 +1,103 @@
+/*
+   给反射调用的事件分发加上递归深度保护。
+
+   实际项目中经常会不小心写出互相触发的事件（比如 OnChange 里又回写了
+   触发它的控件，形成死循环），以前这种情况全靠 callEvent 里的 recover()
+   兜底，最终只会在界面线程上看到一条很难懂的 panic 信息。这里给每个
+   订阅/注册维护一个独立的调用深度，超过 EventRecursionLimit 就不再继续
+   调用，并通过 OnEventRecursion 把事件名暴露出去，方便用户接入自己的
+   日志系统。
+
+   深度计数器不能按 reflect.Value.Pointer() 分桶：对一个绑定方法值
+   （比如 vForm.Method(i) 拿到的 OnButton1Click）取 Pointer()，拿到的是
+   Go runtime 共享的 methodValueCall 跳板地址，不同 receiver、不同方法名
+   的绑定方法都会撞到同一个指针上，按这个分桶等于全局共用一个计数器。
+   这里改成每次注册（guardEventHandler 包一层、或者 TAction 自己）时
+   分配一个只属于这次注册的 *int32 计数器，天然按注册实例隔离，不存在
+   这个问题。
+
+   关键是深度检查必须挂在真正会被 LCL 反复触发的那个调用上，而不是只在
+   associatedEvents 组装阶段调用一次的地方。findAndSetEvent 通过
+   guardEventHandler 把原始 handler 包一层再交给 SetOnXXXX，这样控件每次
+   真正触发事件时都会经过 enterEventCall；TAction.Execute 同样会在每次
+   点击时被重新调用，用自己的 executionDepth 字段走同一条保护逻辑。
+*/
+
+package vcl
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// EventRecursionLimit 单个事件处理函数允许的最大递归深度，默认 128。
+var EventRecursionLimit int32 = 128
+
+// OnEventRecursion 当某个事件处理函数的递归深度超过 EventRecursionLimit 时被调用，
+// eventName 是触发拒绝的事件名称，depth 是当前已经达到的深度。
+var OnEventRecursion func(eventName string, depth int32)
+
+// SetEventRecursionLimit 修改允许的最大递归深度。
+func SetEventRecursionLimit(n int32) {
+	atomic.StoreInt32(&EventRecursionLimit, n)
+}
+
+// enterEventCall 尝试进入一次 eventName 对应的调用。depth 由调用方持有并传入，
+// 每个独立的订阅/注册都要有自己的 depth 计数器，不能全局共享。
+// 返回 false 表示已超过递归深度限制，不应该再调用 handler；
+// leave 用于在调用结束后（无论成功与否）释放本次占用的深度。
+func enterEventCall(eventName string, depth *int32) (ok bool, leave func()) {
+	newDepth := atomic.AddInt32(depth, 1)
+	if newDepth > atomic.LoadInt32(&EventRecursionLimit) {
+		atomic.AddInt32(depth, -1)
+		fmt.Println("vcl: event recursion limit exceeded, event:", eventName, ", depth:", newDepth)
+		if OnEventRecursion != nil {
+			OnEventRecursion(eventName, newDepth)
+		}
+		return false, func() {}
+	}
+
+	return true, func() {
+		atomic.AddInt32(depth, -1)
+	}
+}
+
+// guardEventHandler 把 method 包成一个签名相同的函数，每次被调用时才做递归深度
+// 检查，而不是只在 associatedEvents 组装时检查一次。每次调用 guardEventHandler
+// 都会分配一个只属于这一次注册的 depth 计数器，不同控件、不同事件之间互不影响。
+// findAndSetEvent 把这层包装而不是原始的 method 通过 SetOnXXXX 交给控件，这样
+// 不管控件在运行期间触发了多少次这个事件（包括事件处理函数里又回写了触发它的
+// 控件这种重入场景），每一次真正的分发都会经过这里的深度计数。
+func guardEventHandler(eventName string, method reflect.Value) reflect.Value {
+	if !method.IsValid() || method.Kind() != reflect.Func {
+		return method
+	}
+
+	mt := method.Type()
+	var depth int32
+	return reflect.MakeFunc(mt, func(in []reflect.Value) (out []reflect.Value) {
+		ok, leave := enterEventCall(eventName, &depth)
+		if !ok {
+			return zeroResults(mt)
+		}
+		defer leave()
+		defer func() {
+			if err := recover(); err != nil {
+				fmt.Println("vcl: event handler error, event:", eventName, ", error:", err)
+				out = zeroResults(mt)
+			}
+		}()
+		return method.Call(in)
+	})
+}
+
+// zeroResults 按函数类型构造一组零值返回值，供 guardEventHandler 在拒绝调用
+// 或者 recover 到 panic 时使用。
+func zeroResults(t reflect.Type) []reflect.Value {
+	out := make([]reflect.Value, t.NumOut())
+	for i := range out {
+		out[i] = reflect.Zero(t.Out(i))
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package vcl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGuardEventHandlerIsolatesDepthPerRegistration(t *testing.T) {
+	// 回归用例：以前 enterEventCall 按 handler.Pointer() 分桶，而两个不同的
+	// 绑定方法值拿到的 Pointer() 其实是同一个 runtime 跳板地址，导致不同
+	// 控件/不同事件的深度计数互相干扰。这里用两个完全独立的 guardEventHandler
+	// 包装，限制设为 1，并在 guarded1 的处理函数里嵌套调用 guarded2：
+	// 如果两者共用一个计数器，嵌套的那次调用就会被拒绝。
+	origLimit := EventRecursionLimit
+	defer SetEventRecursionLimit(origLimit)
+	SetEventRecursionLimit(1)
+
+	var calls1, calls2 int
+	guarded2 := guardEventHandler("Event2", reflect.ValueOf(func() { calls2++ }))
+	guarded1 := guardEventHandler("Event1", reflect.ValueOf(func() {
+		calls1++
+		guarded2.Call(nil)
+	}))
+
+	for i := 0; i < 5; i++ {
+		guarded1.Call(nil)
+	}
+
+	if calls1 != 5 {
+		t.Fatalf("calls1 = %d, want 5", calls1)
+	}
+	if calls2 != 5 {
+		t.Fatalf("calls2 = %d, want 5 (should not be blocked by guarded1's depth)", calls2)
+	}
+}
+
+func TestGuardEventHandlerTripsRecursionLimit(t *testing.T) {
+	origLimit := EventRecursionLimit
+	defer SetEventRecursionLimit(origLimit)
+	SetEventRecursionLimit(3)
+
+	origOnEventRecursion := OnEventRecursion
+	defer func() { OnEventRecursion = origOnEventRecursion }()
+
+	var rejectedEvent string
+	var rejectedDepth int32
+	OnEventRecursion = func(eventName string, depth int32) {
+		rejectedEvent = eventName
+		rejectedDepth = depth
+	}
+
+	var guarded reflect.Value
+	var depth int
+	guarded = guardEventHandler("Recursive", reflect.ValueOf(func() {
+		depth++
+		if depth < 10 {
+			guarded.Call(nil)
+		}
+	}))
+
+	guarded.Call(nil)
+
+	if depth != 3 {
+		t.Fatalf("depth = %d, want 3 (recursion should have been cut off at the limit)", depth)
+	}
+	if rejectedEvent != "Recursive" {
+		t.Fatalf("OnEventRecursion event = %q, want %q", rejectedEvent, "Recursive")
+	}
+	if rejectedDepth != 4 {
+		t.Fatalf("OnEventRecursion depth = %d, want 4", rejectedDepth)
+	}
+}
+
+func TestGuardEventHandlerRecoversPanic(t *testing.T) {
+	guarded := guardEventHandler("Panicky", reflect.ValueOf(func() {
+		panic("boom")
+	}))
+
+	// 不应该 panic 出去，callEvent/guardEventHandler 的 recover 应该兜住。
+	guarded.Call(nil)
+}
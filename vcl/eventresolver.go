@@ -0,0 +1,187 @@
+/*
+   associatedEvents 默认要求事件方法按照 "On + 组件名 + 事件类型" 命名，
+   这里把这条硬编码规则抽成 EventNameResolver 接口，方便那些 lint 规则禁止
+   Go 方法名以 On 开头的团队，或者使用其它代码生成器（非 res2go）产出的
+   事件方法接入进来。
+
+   Match 接收的是调用方已经知道的候选组件名（窗体里某个字段/组件的真实名字），
+   由解析器判断某个方法是不是这个组件的事件、以及对应的事件类型是什么 ——
+   这跟原来硬编码的 "On"+组件名 前缀匹配是同一个思路，只是换成可替换的实现，
+   而不是反过来靠一份事件类型后缀表去瞎猜，那样遇到没收录的事件类型
+   （MouseEnter、ContextPopup 之类）或者撞到已收录后缀的子串（比如
+   ButtonMouseEnter 里也有个 "Enter"）就会猜错或者猜漏。
+
+   内置了三种实现：
+     DefaultResolver    现有规则，OnButton1Click -> Button1 / Click
+     SnakeCaseResolver  on_button1_click -> Button1 / Click
+     SuffixResolver     Button1Click_Handler -> Button1 / Click
+
+   可以通过 RegisterEventResolver 注册自定义实现，并在内嵌的 *vcl.TForm 字段上
+   用 resolver 标签指定使用哪一个：
+
+   type TMainForm struct {
+       *vcl.TForm `resolver:"snake"`
+   }
+*/
+
+package vcl
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// EventNameResolver 负责判断一个事件方法是否属于 candidate 这个组件，
+// 并解析出对应的事件类型。
+type EventNameResolver interface {
+	// IsCandidate 判断 method 的命名是否可能是这个解析器认识的事件方法，
+	// 用在 associatedEvents 收集窗体方法那一步，避免把无关方法也当成事件
+	// 方法存进去。不满足 IsCandidate 的方法不会再走到 Match/FormEventName。
+	IsCandidate(method reflect.Method) bool
+	// Match 判断 method 是否是 candidate（组件名，比如 "Button1"）的事件方法，
+	// ok为false表示这个方法不归 candidate 管。
+	Match(method reflect.Method, candidate string) (eventType string, ok bool)
+	// FormEventName 解析窗体自身的事件方法，例如 OnFormClose -> Close。
+	FormEventName(method reflect.Method) (eventType string, ok bool)
+}
+
+// DefaultResolver 是目前默认的命名规则：On + 组件名 + 事件类型。
+type DefaultResolver struct{}
+
+// IsCandidate 实现 EventNameResolver。
+func (DefaultResolver) IsCandidate(method reflect.Method) bool {
+	return strings.HasPrefix(method.Name, "On")
+}
+
+// Match 实现 EventNameResolver。
+func (DefaultResolver) Match(method reflect.Method, candidate string) (eventType string, ok bool) {
+	prefix := "On" + candidate
+	if !strings.HasPrefix(method.Name, prefix) {
+		return "", false
+	}
+	return method.Name[len(prefix):], true
+}
+
+// FormEventName 实现 EventNameResolver，窗体固定用 Form 作为组件名。
+func (DefaultResolver) FormEventName(method reflect.Method) (eventType string, ok bool) {
+	return DefaultResolver{}.Match(method, "Form")
+}
+
+// SnakeCaseResolver 支持 on_button1_click 这样的命名，
+// 用来对接那些不允许大驼峰开头带 On 的代码规范。
+// on_ 开头的方法是未导出的，associatedEvents 通过反射只能看到导出的方法，
+// 所以不会用它关联到任何事件；Match/FormEventName 只在被直接调用时起作用。
+type SnakeCaseResolver struct{}
+
+// IsCandidate 实现 EventNameResolver。
+func (SnakeCaseResolver) IsCandidate(method reflect.Method) bool {
+	return strings.HasPrefix(method.Name, "on_")
+}
+
+// Match 实现 EventNameResolver。
+func (SnakeCaseResolver) Match(method reflect.Method, candidate string) (eventType string, ok bool) {
+	prefix := "on_" + toSnakeCase(candidate) + "_"
+	if !strings.HasPrefix(method.Name, prefix) {
+		return "", false
+	}
+	rest := method.Name[len(prefix):]
+	if rest == "" {
+		return "", false
+	}
+	return snakeToPascal(rest), true
+}
+
+// FormEventName 实现 EventNameResolver。
+func (SnakeCaseResolver) FormEventName(method reflect.Method) (eventType string, ok bool) {
+	return SnakeCaseResolver{}.Match(method, "Form")
+}
+
+// SuffixResolver 支持 Button1Click_Handler 这样把 On 换成后缀 _Handler 的命名。
+type SuffixResolver struct{}
+
+const suffixResolverSuffix = "_Handler"
+
+// IsCandidate 实现 EventNameResolver。
+func (SuffixResolver) IsCandidate(method reflect.Method) bool {
+	return strings.HasSuffix(method.Name, suffixResolverSuffix)
+}
+
+// Match 实现 EventNameResolver。
+func (SuffixResolver) Match(method reflect.Method, candidate string) (eventType string, ok bool) {
+	if !strings.HasPrefix(method.Name, candidate) || !strings.HasSuffix(method.Name, suffixResolverSuffix) {
+		return "", false
+	}
+	rest := method.Name[len(candidate) : len(method.Name)-len(suffixResolverSuffix)]
+	if rest == "" {
+		return "", false
+	}
+	return rest, true
+}
+
+// FormEventName 实现 EventNameResolver。
+func (SuffixResolver) FormEventName(method reflect.Method) (eventType string, ok bool) {
+	return SuffixResolver{}.Match(method, "Form")
+}
+
+// toSnakeCase 把 Button1、SaveButton 这样的组件名转成 button1、save_button。
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+// snakeToPascal 把 click、mouse_down 这样的下划线命名转成 Click、MouseDown。
+func snakeToPascal(name string) string {
+	parts := strings.Split(name, "_")
+	var b strings.Builder
+	for _, p := range parts {
+		b.WriteString(strings.Title(p))
+	}
+	return b.String()
+}
+
+var (
+	eventResolversMu sync.RWMutex
+	eventResolvers   = map[string]EventNameResolver{
+		"default": DefaultResolver{},
+		"snake":   SnakeCaseResolver{},
+		"suffix":  SuffixResolver{},
+	}
+)
+
+// RegisterEventResolver 注册一个自定义的 EventNameResolver，
+// 之后就可以在内嵌的 *vcl.TForm 字段上用 `resolver:"name"` 标签引用它。
+func RegisterEventResolver(name string, r EventNameResolver) {
+	eventResolversMu.Lock()
+	eventResolvers[name] = r
+	eventResolversMu.Unlock()
+}
+
+// resolveEventResolver 根据窗体类型上内嵌 *vcl.TForm 字段的 resolver 标签
+// 选出要使用的 EventNameResolver，没有指定时回退到 DefaultResolver。
+func resolveEventResolver(vt reflect.Type) EventNameResolver {
+	st := vt.Elem()
+	for i := 0; i < st.NumField(); i++ {
+		f := st.Field(i)
+		if !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("resolver")
+		if tag == "" {
+			continue
+		}
+		eventResolversMu.RLock()
+		r, ok := eventResolvers[tag]
+		eventResolversMu.RUnlock()
+		if ok {
+			return r
+		}
+	}
+	return DefaultResolver{}
+}
@@ -0,0 +1,111 @@
+package vcl
+
+import (
+	"reflect"
+	"testing"
+)
+
+// method 构造一个只填了 Name 字段的 reflect.Method，足够喂给
+// EventNameResolver 的实现，因为它们只读 Name。
+func method(name string) reflect.Method {
+	return reflect.Method{Name: name}
+}
+
+func TestDefaultResolverMatch(t *testing.T) {
+	cases := []struct {
+		methodName string
+		candidate  string
+		wantType   string
+		wantOK     bool
+	}{
+		{"OnButton1Click", "Button1", "Click", true},
+		// 回归用例：OnMouseEnter 以前会被旧的后缀白名单按 "Enter" 错误切开成
+		// Button1Mouse / Enter，现在应该按真实组件名精确匹配。
+		{"OnButton1MouseEnter", "Button1", "MouseEnter", true},
+		// Match 只按 "On"+候选组件名 做前缀匹配：候选名是 Button1Mouse 时，
+		// 同一个方法被当成 Button1Mouse 的 Enter 事件。
+		{"OnButton1MouseEnter", "Button1Mouse", "Enter", true},
+		// 不在任何后缀表里的自定义事件类型现在也能正常识别。
+		{"OnButton1ContextPopup", "Button1", "ContextPopup", true},
+		{"OnButton2Click", "Button1", "", false},
+		{"SomeOtherMethod", "Button1", "", false},
+	}
+
+	for _, c := range cases {
+		gotType, gotOK := (DefaultResolver{}).Match(method(c.methodName), c.candidate)
+		if gotOK != c.wantOK || gotType != c.wantType {
+			t.Errorf("Match(%q, %q) = (%q, %v), want (%q, %v)",
+				c.methodName, c.candidate, gotType, gotOK, c.wantType, c.wantOK)
+		}
+	}
+}
+
+func TestDefaultResolverIsCandidate(t *testing.T) {
+	if !(DefaultResolver{}).IsCandidate(method("OnButton1Click")) {
+		t.Errorf("IsCandidate(OnButton1Click) should be true")
+	}
+	if (DefaultResolver{}).IsCandidate(method("on_button1_click")) {
+		t.Errorf("IsCandidate(on_button1_click) should be false for DefaultResolver")
+	}
+}
+
+func TestDefaultResolverFormEventName(t *testing.T) {
+	eventType, ok := (DefaultResolver{}).FormEventName(method("OnFormClose"))
+	if !ok || eventType != "Close" {
+		t.Errorf("FormEventName(OnFormClose) = (%q, %v), want (Close, true)", eventType, ok)
+	}
+
+	if _, ok := (DefaultResolver{}).FormEventName(method("OnButton1Click")); ok {
+		t.Errorf("FormEventName(OnButton1Click) should not match")
+	}
+}
+
+func TestSnakeCaseResolverIsCandidate(t *testing.T) {
+	// 回归用例：以前 associatedEvents 收集事件方法那一步硬编码了
+	// strings.HasPrefix(m.Name, "On")，SnakeCaseResolver/SuffixResolver
+	// 命名的方法永远过不了这一关，resolver 根本没机会参与判断。
+	if !(SnakeCaseResolver{}).IsCandidate(method("on_button1_click")) {
+		t.Errorf("IsCandidate(on_button1_click) should be true")
+	}
+	if (SnakeCaseResolver{}).IsCandidate(method("OnButton1Click")) {
+		t.Errorf("IsCandidate(OnButton1Click) should be false for SnakeCaseResolver")
+	}
+}
+
+func TestSnakeCaseResolverMatch(t *testing.T) {
+	// 回归用例：以前这里返回小写的组件名（"button1"），调用方拿真实的
+	// component.Name()（"Button1"）去比较永远不相等，导致这个解析器完全不可用。
+	eventType, ok := (SnakeCaseResolver{}).Match(method("on_button1_click"), "Button1")
+	if !ok || eventType != "Click" {
+		t.Errorf("Match(on_button1_click, Button1) = (%q, %v), want (Click, true)", eventType, ok)
+	}
+
+	if _, ok := (SnakeCaseResolver{}).Match(method("on_button2_click"), "Button1"); ok {
+		t.Errorf("Match(on_button2_click, Button1) should not match")
+	}
+
+	eventType, ok = (SnakeCaseResolver{}).FormEventName(method("on_form_close"))
+	if !ok || eventType != "Close" {
+		t.Errorf("FormEventName(on_form_close) = (%q, %v), want (Close, true)", eventType, ok)
+	}
+}
+
+func TestSuffixResolverIsCandidate(t *testing.T) {
+	if !(SuffixResolver{}).IsCandidate(method("Button1Click_Handler")) {
+		t.Errorf("IsCandidate(Button1Click_Handler) should be true")
+	}
+	if (SuffixResolver{}).IsCandidate(method("OnButton1Click")) {
+		t.Errorf("IsCandidate(OnButton1Click) should be false for SuffixResolver")
+	}
+}
+
+func TestSuffixResolverMatch(t *testing.T) {
+	eventType, ok := (SuffixResolver{}).Match(method("Button1Click_Handler"), "Button1")
+	if !ok || eventType != "Click" {
+		t.Errorf("Match(Button1Click_Handler, Button1) = (%q, %v), want (Click, true)", eventType, ok)
+	}
+
+	if _, ok := (SuffixResolver{}).Match(method("Button1Click"), "Button1"); ok {
+		t.Errorf("Match(Button1Click, Button1) should not match without the _Handler suffix")
+	}
+}